@@ -0,0 +1,156 @@
+// Package assert provides a lightweight assertion facade for the ASSERT
+// type parameter of a testbuilder.TestCase, so callers don't have to
+// hand-roll one.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// DiffFunc renders a human-readable diff between an expected and an actual
+// value when Equal/NotEqual fail. Swap it out to plug in a preferred diff
+// renderer.
+var DiffFunc = func(expected, actual any) string {
+	return fmt.Sprintf("expected: %#v\nactual:   %#v", expected, actual)
+}
+
+// Assertion is the canonical shape for the ASSERT type parameter: a
+// function that receives the built SUT/STATE plus an A to make assertions
+// with, suitable for TestCase.WithAssertion.
+type Assertion[SUT any, STATE any] func(t testing.TB, a *A, sut SUT, state STATE)
+
+// A is a small assertion vocabulary. The zero value reports failures with
+// t.Error (Should semantics); call Must() for a copy that reports with
+// t.Fatal instead.
+type A struct {
+	fatal bool
+}
+
+// Must returns a copy of a that reports failures via t.Fatal, stopping the
+// test immediately.
+func (a A) Must() *A {
+	a.fatal = true
+	return &a
+}
+
+// Should returns a copy of a that reports failures via t.Error, letting the
+// test continue so later assertions still run.
+func (a A) Should() *A {
+	a.fatal = false
+	return &a
+}
+
+func (a *A) fail(t testing.TB, format string, args ...any) {
+	t.Helper()
+	if a.fatal {
+		t.Fatalf(format, args...)
+		return
+	}
+	t.Errorf(format, args...)
+}
+
+// Equal asserts that expected and actual are deeply equal.
+func (a *A) Equal(t testing.TB, expected, actual any) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		a.fail(t, "values are not equal\n%s", DiffFunc(expected, actual))
+	}
+}
+
+// NotEqual asserts that expected and actual are not deeply equal.
+func (a *A) NotEqual(t testing.TB, expected, actual any) {
+	t.Helper()
+	if reflect.DeepEqual(expected, actual) {
+		a.fail(t, "values are equal: %#v", actual)
+	}
+}
+
+// Contain asserts that collection (a slice, array, map or string) contains
+// element.
+func (a *A) Contain(t testing.TB, collection, element any) {
+	t.Helper()
+	if !contains(collection, element) {
+		a.fail(t, "%#v does not contain %#v", collection, element)
+	}
+}
+
+func contains(collection, element any) bool {
+	v := reflect.ValueOf(collection)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if reflect.DeepEqual(v.Index(i).Interface(), element) {
+				return true
+			}
+		}
+		return false
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if reflect.DeepEqual(v.MapIndex(k).Interface(), element) {
+				return true
+			}
+		}
+		return false
+	case reflect.String:
+		s, ok := element.(string)
+		return ok && strings.Contains(v.String(), s)
+	default:
+		return false
+	}
+}
+
+// True asserts that cond is true.
+func (a *A) True(t testing.TB, cond bool, msgAndArgs ...any) {
+	t.Helper()
+	if !cond {
+		a.fail(t, "expected condition to be true%s", formatMsg(msgAndArgs))
+	}
+}
+
+func formatMsg(msgAndArgs []any) string {
+	if len(msgAndArgs) == 0 {
+		return ""
+	}
+	return ": " + fmt.Sprint(msgAndArgs...)
+}
+
+// ErrorIs asserts that errors.Is(err, target) holds.
+func (a *A) ErrorIs(t testing.TB, err, target error) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		a.fail(t, "error %v does not wrap target %v", err, target)
+	}
+}
+
+// AnyOf asserts that actual deeply equals at least one of candidates.
+func (a *A) AnyOf(t testing.TB, actual any, candidates ...any) {
+	t.Helper()
+	for _, candidate := range candidates {
+		if reflect.DeepEqual(actual, candidate) {
+			return
+		}
+	}
+	a.fail(t, "%#v did not match any of %#v", actual, candidates)
+}
+
+// Eventually asserts that cond returns true before timeout elapses,
+// polling every interval.
+func (a *A) Eventually(t testing.TB, timeout, interval time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		if cond() {
+			return
+		}
+		if time.Now().After(deadline) {
+			a.fail(t, "condition was not met within %s", timeout)
+			return
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,166 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeTB is a minimal testing.TB that records whether Error or Fatal was
+// called, instead of actually failing or aborting the test that uses it.
+type fakeTB struct {
+	testing.TB
+	errorCalled bool
+	fatalCalled bool
+	msg         string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errorCalled = true
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.fatalCalled = true
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func (f *fakeTB) failed() bool {
+	return f.errorCalled || f.fatalCalled
+}
+
+func TestA_Equal(t *testing.T) {
+	tb := &fakeTB{}
+	(&A{}).Equal(tb, 1, 1)
+	if tb.failed() {
+		t.Fatalf("Equal(1, 1) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).Equal(tb, 1, 2)
+	if !tb.errorCalled {
+		t.Fatalf("Equal(1, 2) did not report a failure")
+	}
+}
+
+func TestA_NotEqual(t *testing.T) {
+	tb := &fakeTB{}
+	(&A{}).NotEqual(tb, 1, 2)
+	if tb.failed() {
+		t.Fatalf("NotEqual(1, 2) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).NotEqual(tb, 1, 1)
+	if !tb.errorCalled {
+		t.Fatalf("NotEqual(1, 1) did not report a failure")
+	}
+}
+
+func TestA_Contain(t *testing.T) {
+	tb := &fakeTB{}
+	(&A{}).Contain(tb, []int{1, 2, 3}, 2)
+	if tb.failed() {
+		t.Fatalf("Contain([1,2,3], 2) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).Contain(tb, []int{1, 2, 3}, 4)
+	if !tb.errorCalled {
+		t.Fatalf("Contain([1,2,3], 4) did not report a failure")
+	}
+
+	tb = &fakeTB{}
+	(&A{}).Contain(tb, map[string]int{"a": 1}, 1)
+	if tb.failed() {
+		t.Fatalf("Contain(map, 1) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).Contain(tb, "hello world", "world")
+	if tb.failed() {
+		t.Fatalf("Contain(string, substring) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).Contain(tb, "hello world", "bye")
+	if !tb.errorCalled {
+		t.Fatalf("Contain(string, missing substring) did not report a failure")
+	}
+}
+
+func TestA_True(t *testing.T) {
+	tb := &fakeTB{}
+	(&A{}).True(tb, true)
+	if tb.failed() {
+		t.Fatalf("True(true) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).True(tb, false, "extra context")
+	if !tb.errorCalled {
+		t.Fatalf("True(false) did not report a failure")
+	}
+}
+
+func TestA_ErrorIs(t *testing.T) {
+	target := errors.New("boom")
+	wrapped := fmt.Errorf("wrapping: %w", target)
+
+	tb := &fakeTB{}
+	(&A{}).ErrorIs(tb, wrapped, target)
+	if tb.failed() {
+		t.Fatalf("ErrorIs(wrapped, target) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).ErrorIs(tb, errors.New("other"), target)
+	if !tb.errorCalled {
+		t.Fatalf("ErrorIs(other, target) did not report a failure")
+	}
+}
+
+func TestA_AnyOf(t *testing.T) {
+	tb := &fakeTB{}
+	(&A{}).AnyOf(tb, 2, 1, 2, 3)
+	if tb.failed() {
+		t.Fatalf("AnyOf(2, 1, 2, 3) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).AnyOf(tb, 4, 1, 2, 3)
+	if !tb.errorCalled {
+		t.Fatalf("AnyOf(4, 1, 2, 3) did not report a failure")
+	}
+}
+
+func TestA_Eventually(t *testing.T) {
+	tb := &fakeTB{}
+	(&A{}).Eventually(tb, 50*time.Millisecond, time.Millisecond, func() bool { return true })
+	if tb.failed() {
+		t.Fatalf("Eventually(always true) reported a failure: %s", tb.msg)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).Eventually(tb, 5*time.Millisecond, time.Millisecond, func() bool { return false })
+	if !tb.errorCalled {
+		t.Fatalf("Eventually(always false) did not report a failure")
+	}
+}
+
+func TestA_MustReportsFatal_ShouldReportsError(t *testing.T) {
+	tb := &fakeTB{}
+	(&A{}).Must().Equal(tb, 1, 2)
+	if !tb.fatalCalled || tb.errorCalled {
+		t.Fatalf("Must().Equal mismatch: fatalCalled=%v errorCalled=%v, want fatal only", tb.fatalCalled, tb.errorCalled)
+	}
+
+	tb = &fakeTB{}
+	(&A{}).Should().Equal(tb, 1, 2)
+	if tb.fatalCalled || !tb.errorCalled {
+		t.Fatalf("Should().Equal mismatch: fatalCalled=%v errorCalled=%v, want error only", tb.fatalCalled, tb.errorCalled)
+	}
+}
@@ -0,0 +1,72 @@
+package assert
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testbuilder "github.com/Emptyless/go-testbuilder"
+)
+
+// MarshalFunc renders an extracted snapshot value before it's written to,
+// or compared against, its golden file. JSON by default; swap it out to
+// use a different golden-file format.
+var MarshalFunc = func(v any) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+// updateEnv is the environment variable that, when set to "1", makes
+// Snapshot rewrite golden files instead of comparing against them.
+const updateEnv = "TESTBUILDER_UPDATE"
+
+func goldenPath(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+// Snapshot returns an Assertion that extracts a value from sut/state,
+// marshals it with MarshalFunc, and compares it against
+// testdata/<name>.golden, failing with a DiffFunc-rendered diff on
+// mismatch. Set TESTBUILDER_UPDATE=1 to (re)write the golden file instead
+// of comparing against it.
+func Snapshot[SUT any, STATE any](name string, extractor func(sut SUT, state STATE) any) Assertion[SUT, STATE] {
+	return func(t testing.TB, a *A, sut SUT, state STATE) {
+		t.Helper()
+
+		actual, err := MarshalFunc(extractor(sut, state))
+		if err != nil {
+			a.fail(t, "snapshot %q: marshal failed: %v", name, err)
+			return
+		}
+
+		path := goldenPath(name)
+		if os.Getenv(updateEnv) == "1" {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				a.fail(t, "snapshot %q: creating testdata dir failed: %v", name, err)
+				return
+			}
+			if err := os.WriteFile(path, actual, 0o644); err != nil {
+				a.fail(t, "snapshot %q: writing golden file %s failed: %v", name, path, err)
+			}
+			return
+		}
+
+		expected, err := os.ReadFile(path)
+		if err != nil {
+			a.fail(t, "snapshot %q: reading golden file %s failed: %v (re-run with %s=1 to create it)", name, path, err, updateEnv)
+			return
+		}
+
+		if string(expected) != string(actual) {
+			a.fail(t, "snapshot %q does not match golden file %s\n%s", name, path, DiffFunc(string(expected), string(actual)))
+		}
+	}
+}
+
+// WithSnapshot is the TestCase.WithSnapshot counterpart for snapshot
+// assertions: Go doesn't allow adding a method to TestCase that narrows
+// its ASSERT type parameter, so this free function plays that role,
+// setting tc's Assertion to Snapshot(name, extractor).
+func WithSnapshot[SUT any, STATE any](tc *testbuilder.TestCase[SUT, STATE, Assertion[SUT, STATE]], name string, extractor func(sut SUT, state STATE) any) *testbuilder.TestCase[SUT, STATE, Assertion[SUT, STATE]] {
+	return tc.WithAssertion(Snapshot[SUT, STATE](name, extractor))
+}
@@ -0,0 +1,111 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withTempWorkdir chdirs into a fresh temp directory for the duration of
+// the test, so Snapshot's relative testdata/ path is isolated per test.
+func withTempWorkdir(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(orig)
+	})
+}
+
+func TestSnapshot_MissingGoldenFile_Fails(t *testing.T) {
+	withTempWorkdir(t)
+
+	tb := &fakeTB{}
+	fn := Snapshot[int, struct{}]("missing", func(sut int, state struct{}) any { return sut })
+	fn(tb, &A{}, 42, struct{}{})
+
+	if !tb.errorCalled {
+		t.Fatalf("expected a failure for a missing golden file, got none")
+	}
+}
+
+func TestSnapshot_UpdateCreatesGoldenFile(t *testing.T) {
+	withTempWorkdir(t)
+	t.Setenv(updateEnv, "1")
+
+	tb := &fakeTB{}
+	fn := Snapshot[int, struct{}]("created", func(sut int, state struct{}) any { return sut })
+	fn(tb, &A{}, 42, struct{}{})
+
+	if tb.failed() {
+		t.Fatalf("update run reported a failure: %s", tb.msg)
+	}
+
+	data, err := os.ReadFile(goldenPath("created"))
+	if err != nil {
+		t.Fatalf("golden file was not written: %v", err)
+	}
+
+	want, _ := MarshalFunc(42)
+	if string(data) != string(want) {
+		t.Fatalf("golden file = %s, want %s", data, want)
+	}
+}
+
+func TestSnapshot_MatchingGolden_Passes(t *testing.T) {
+	withTempWorkdir(t)
+
+	if err := os.MkdirAll("testdata", 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	data, _ := MarshalFunc(42)
+	if err := os.WriteFile(filepath.Join("testdata", "match.golden"), data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	tb := &fakeTB{}
+	fn := Snapshot[int, struct{}]("match", func(sut int, state struct{}) any { return sut })
+	fn(tb, &A{}, 42, struct{}{})
+
+	if tb.failed() {
+		t.Fatalf("matching snapshot reported a failure: %s", tb.msg)
+	}
+}
+
+func TestSnapshot_MismatchedGolden_FailsWithDiff(t *testing.T) {
+	withTempWorkdir(t)
+
+	if err := os.MkdirAll("testdata", 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	data, _ := MarshalFunc(1)
+	if err := os.WriteFile(filepath.Join("testdata", "mismatch.golden"), data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	diffCalled := false
+	origDiff := DiffFunc
+	DiffFunc = func(expected, actual any) string {
+		diffCalled = true
+		return origDiff(expected, actual)
+	}
+	t.Cleanup(func() { DiffFunc = origDiff })
+
+	tb := &fakeTB{}
+	fn := Snapshot[int, struct{}]("mismatch", func(sut int, state struct{}) any { return sut })
+	fn(tb, &A{}, 2, struct{}{})
+
+	if !tb.errorCalled {
+		t.Fatalf("mismatched snapshot did not report a failure")
+	}
+	if !diffCalled {
+		t.Fatalf("mismatched snapshot did not render a diff via DiffFunc")
+	}
+}
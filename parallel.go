@@ -0,0 +1,189 @@
+package testbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// defaultCaseDuration is the cost of a case with no recorded duration when
+// no durations are recorded at all; otherwise such a case costs the average
+// of the recorded ones.
+const defaultCaseDuration = time.Second
+
+// WithTimingCache points RunParallel at a JSON file it should read known
+// per-case durations from before partitioning, and write updated durations
+// to after each run, so later runs converge to balanced shards.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) WithTimingCache(path string) *TestsBuilder[SUT, STATE, ASSERT] {
+	ts.timingCachePath = path
+	return ts
+}
+
+// SetShard restricts this builder to the index-th of total cost-balanced
+// shards, computed with the same partitioning RunParallel uses, so CI
+// matrices can fan a single suite out across machines.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) SetShard(index, total int) *TestsBuilder[SUT, STATE, ASSERT] {
+	ts.shardIndex = index
+	ts.shardTotal = total
+	return ts
+}
+
+// RunParallel runs every registered case under t, grouped into workers
+// buckets using the greedy longest-processing-time heuristic (sort by
+// descending known duration, assign each to the currently shortest
+// bucket) so wall time across buckets is balanced; cases with no recorded
+// duration get an average default. Each bucket is its own t.Parallel()
+// subtest, so buckets run concurrently while the cases within one bucket
+// run sequentially, mirroring how one CI worker machine would process its
+// shard; cases under a Context marked Parallel() call t.Parallel()
+// themselves and so run concurrently within their bucket. If WithTimingCache was set, durations are loaded before
+// partitioning and the freshly measured durations are persisted back once
+// every case has finished.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) RunParallel(t *testing.T, workers int) {
+	t.Helper()
+
+	leaves := ts.collectLeaves("", nil)
+	durations := ts.loadTimings()
+
+	if ts.shardTotal > 1 {
+		if ts.shardIndex < 0 || ts.shardIndex >= ts.shardTotal {
+			t.Fatalf("testbuilder: shard index %d out of range for %d total shards", ts.shardIndex, ts.shardTotal)
+		}
+		shards := partition(leaves, ts.shardTotal, durations)
+		leaves = shards[ts.shardIndex]
+	}
+
+	var mu sync.Mutex
+	measured := make(map[string]time.Duration, len(leaves))
+
+	// Each worker subtest is marked parallel so buckets run concurrently;
+	// the leaves inside one bucket run sequentially (unless a Parallel()
+	// context makes them call t.Parallel()), the way a single CI worker
+	// machine would process its balanced shard.
+	groups := partition(leaves, workers, durations)
+	for i, group := range groups {
+		group := group
+		t.Run(fmt.Sprintf("worker-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			for _, lf := range group {
+				lf := lf
+				t.Run(lf.name, func(t *testing.T) {
+					start := time.Now()
+					ts.build(t, lf)
+					elapsed := time.Since(start)
+
+					mu.Lock()
+					measured[lf.name] = elapsed
+					mu.Unlock()
+				})
+			}
+		})
+	}
+
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for name, d := range measured {
+			durations[name] = d
+		}
+		ts.saveTimings(durations)
+	})
+}
+
+// partition splits leaves into workers buckets using the greedy
+// longest-processing-time heuristic: sort by descending cost, then assign
+// each leaf to the bucket with the smallest running total. Leaves without
+// a known duration cost the average of the known ones (or
+// defaultCaseDuration if none are known).
+func partition[SUT any, STATE any, ASSERT any](leaves []leaf[SUT, STATE, ASSERT], workers int, durations map[string]time.Duration) [][]leaf[SUT, STATE, ASSERT] {
+	if workers < 1 {
+		workers = 1
+	}
+
+	avg := averageDuration(durations)
+
+	type costed struct {
+		lf   leaf[SUT, STATE, ASSERT]
+		cost time.Duration
+	}
+
+	sorted := make([]costed, len(leaves))
+	for i, lf := range leaves {
+		cost, ok := durations[lf.name]
+		if !ok {
+			cost = avg
+		}
+		sorted[i] = costed{lf: lf, cost: cost}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].cost > sorted[j].cost
+	})
+
+	groups := make([][]leaf[SUT, STATE, ASSERT], workers)
+	totals := make([]time.Duration, workers)
+	for _, c := range sorted {
+		shortest := 0
+		for i := 1; i < workers; i++ {
+			if totals[i] < totals[shortest] {
+				shortest = i
+			}
+		}
+		groups[shortest] = append(groups[shortest], c.lf)
+		totals[shortest] += c.cost
+	}
+	return groups
+}
+
+func averageDuration(durations map[string]time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return defaultCaseDuration
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
+
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) loadTimings() map[string]time.Duration {
+	durations := map[string]time.Duration{}
+	if ts.timingCachePath == "" {
+		return durations
+	}
+
+	data, err := os.ReadFile(ts.timingCachePath)
+	if err != nil {
+		return durations
+	}
+
+	raw := map[string]int64{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return durations
+	}
+	for name, ns := range raw {
+		durations[name] = time.Duration(ns)
+	}
+	return durations
+}
+
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) saveTimings(durations map[string]time.Duration) {
+	if ts.timingCachePath == "" {
+		return
+	}
+
+	raw := make(map[string]int64, len(durations))
+	for name, d := range durations {
+		raw[name] = int64(d)
+	}
+
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(ts.timingCachePath, data, 0o644)
+}
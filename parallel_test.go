@@ -0,0 +1,179 @@
+package testbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPartition_BalancesByDescendingCost(t *testing.T) {
+	mk := func(name string) leaf[struct{}, struct{}, struct{}] {
+		return leaf[struct{}, struct{}, struct{}]{name: name}
+	}
+
+	leaves := []leaf[struct{}, struct{}, struct{}]{
+		mk("a"), mk("b"), mk("c"), mk("d"),
+	}
+	durations := map[string]time.Duration{
+		"a": 5 * time.Second,
+		"b": 3 * time.Second,
+		"c": 3 * time.Second,
+		"d": 1 * time.Second,
+	}
+
+	groups := partition(leaves, 2, durations)
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+
+	totals := make([]time.Duration, len(groups))
+	for i, group := range groups {
+		for _, lf := range group {
+			totals[i] += durations[lf.name]
+		}
+	}
+
+	diff := totals[0] - totals[1]
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > 2*time.Second {
+		t.Fatalf("groups unbalanced: totals = %v (leaves %v)", totals, groups)
+	}
+}
+
+// TestRunParallel_ParallelContextAncestor_DoesNotPanic is a regression test
+// for combining RunParallel with a Context(...).Parallel() ancestor: the
+// leaf's *testing.T used to have t.Parallel() called once by RunParallel
+// and again by the ancestor's Parallel(), which panics.
+func TestRunParallel_ParallelContextAncestor_DoesNotPanic(t *testing.T) {
+	b := &TestsBuilder[struct{}, struct{}, struct{}]{}
+	b.Context("group", func(cb *TestsBuilder[struct{}, struct{}, struct{}]) {
+		cb.Parallel()
+		cb.Register("case-a")
+		cb.Register("case-b")
+	})
+
+	t.Run("outer", func(t *testing.T) {
+		b.RunParallel(t, 2)
+	})
+}
+
+// TestRunParallel_InvalidShardIndex_Fails is a regression test for a bug
+// where an out-of-range SetShard index silently produced zero subtests
+// instead of failing, which would let a misconfigured CI matrix entry
+// report green while covering nothing. RunParallel calls t.Fatalf, which
+// would fail this test's own *testing.T along with it if invoked
+// in-process, so it's exercised out-of-process the way the standard
+// library tests functions that call Fatal/os.Exit: re-exec this test
+// binary restricted to TestHelperProcess_InvalidShardIndex and inspect
+// its outcome instead.
+func TestRunParallel_InvalidShardIndex_Fails(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess_InvalidShardIndex", "-test.v")
+	cmd.Env = append(os.Environ(), "TESTBUILDER_WANT_HELPER_PROCESS=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("RunParallel with an out-of-range shard index did not fail; output:\n%s", out)
+	}
+	if !strings.Contains(string(out), "shard index") {
+		t.Fatalf("failure output does not mention the shard index problem; output:\n%s", out)
+	}
+}
+
+// TestHelperProcess_InvalidShardIndex is not a real test: it's only run,
+// out-of-process, by TestRunParallel_InvalidShardIndex_Fails above.
+func TestHelperProcess_InvalidShardIndex(t *testing.T) {
+	if os.Getenv("TESTBUILDER_WANT_HELPER_PROCESS") != "1" {
+		t.Skip("not invoked as a helper process")
+	}
+
+	b := &TestsBuilder[struct{}, struct{}, struct{}]{}
+	b.Register("a")
+	b.SetShard(5, 2)
+	b.RunParallel(t, 1)
+}
+
+func TestSetShard_RunsOnlyAssignedLeaves(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[int][]string{}
+
+	newBuilder := func(shardIdx int) *TestsBuilder[struct{}, struct{}, struct{}] {
+		b := &TestsBuilder[struct{}, struct{}, struct{}]{}
+		for _, n := range []string{"a", "b", "c", "d"} {
+			n := n
+			// SpecificBuilder, unlike StateBuilder, only runs for this exact
+			// case rather than for every later sibling too, so it's the
+			// right hook for recording which leaves actually ran.
+			b.Register(n).WithSpecificBuilder(func(t testing.TB, sut *struct{}, state *struct{}) {
+				mu.Lock()
+				seen[shardIdx] = append(seen[shardIdx], n)
+				mu.Unlock()
+			})
+		}
+		b.SetShard(shardIdx, 2)
+		return b
+	}
+
+	for _, idx := range []int{0, 1} {
+		idx := idx
+		b := newBuilder(idx)
+		t.Run(fmt.Sprintf("shard-%d", idx), func(t *testing.T) {
+			b.RunParallel(t, 1)
+		})
+	}
+
+	if len(seen[0])+len(seen[1]) != 4 {
+		t.Fatalf("shards covered %d+%d leaves, want 4 total: %v", len(seen[0]), len(seen[1]), seen)
+	}
+
+	inShard0 := map[string]bool{}
+	for _, n := range seen[0] {
+		inShard0[n] = true
+	}
+	for _, n := range seen[1] {
+		if inShard0[n] {
+			t.Fatalf("case %q ran in both shards: %v", n, seen)
+		}
+	}
+}
+
+func TestWithTimingCache_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timings.json")
+
+	b := &TestsBuilder[struct{}, struct{}, struct{}]{}
+	b.Register("a")
+	b.Register("b")
+	b.WithTimingCache(path)
+
+	t.Run("first-run", func(t *testing.T) {
+		b.RunParallel(t, 2)
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("timing cache was not written: %v", err)
+	}
+
+	var raw map[string]int64
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("timing cache is not valid JSON: %v", err)
+	}
+	for _, name := range []string{"a", "b"} {
+		if _, ok := raw[name]; !ok {
+			t.Fatalf("timing cache missing a duration for %q: %v", name, raw)
+		}
+	}
+
+	b2 := &TestsBuilder[struct{}, struct{}, struct{}]{}
+	b2.WithTimingCache(path)
+	loaded := b2.loadTimings()
+	if len(loaded) != 2 {
+		t.Fatalf("loadTimings() = %v, want 2 entries", loaded)
+	}
+}
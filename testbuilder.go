@@ -5,8 +5,32 @@ import (
 	"testing"
 )
 
+// TestsBuilder accumulates TestCases and nested Contexts. A TestsBuilder
+// returned by Context is itself a full TestsBuilder scoped to that branch:
+// it inherits the parent's accumulated StateBuilders but cannot affect
+// siblings outside its branch, so subjects stay immutable across branches.
 type TestsBuilder[SUT any, STATE any, ASSERT any] struct {
+	// TestCases registered directly on this builder, in registration order.
 	TestCases []*TestCase[SUT, STATE, ASSERT]
+
+	name       string
+	entries    []entry[SUT, STATE, ASSERT]
+	beforeEach []func(t testing.TB, sut *SUT, state *STATE)
+	afterEach  []func(t testing.TB, sut *SUT, state *STATE)
+	around     []func(t testing.TB, sut *SUT, state *STATE, next func())
+	parallel   bool
+
+	timingCachePath string
+	shardIndex      int
+	shardTotal      int
+}
+
+// entry is either a registered TestCase or a nested Context, kept in a
+// single ordered list so accumulation still only sees entries that were
+// registered before it, the same way the flat builder did.
+type entry[SUT any, STATE any, ASSERT any] struct {
+	testCase *TestCase[SUT, STATE, ASSERT]
+	child    *TestsBuilder[SUT, STATE, ASSERT]
 }
 
 type TestData[SUT any, STATE any, ASSERT any] struct {
@@ -15,71 +39,310 @@ type TestData[SUT any, STATE any, ASSERT any] struct {
 	Assert ASSERT
 }
 
+// TestCase is an immutable value: every With* method returns a shallow
+// copy rather than mutating the receiver, so a partially-built TestCase
+// can be reused as a template for many derived cases via Clone or
+// TestsBuilder.Extend without the template itself changing underneath you.
 type TestCase[SUT any, STATE any, ASSERT any] struct {
 	// TestName for current test
 	TestName string
 	// StateBuilder that is subsequently used to build up state for the tests
-	StateBuilder func(t *testing.T, sut *SUT, state *STATE)
+	StateBuilder func(t testing.TB, sut *SUT, state *STATE)
 	// SpecificBuilder is only run for this case
-	SpecificBuilder func(t *testing.T, sut *SUT, state *STATE)
+	SpecificBuilder func(t testing.TB, sut *SUT, state *STATE)
 	// Assertion
 	Assertion ASSERT
+
+	// owner/entrySlot/caseSlot let a committed copy write itself back into
+	// the builder slot it was registered in, so With* calls that chain off
+	// a registered case are visible to Tests()/Benchmarks()/Fuzz() without
+	// the caller having to re-register anything. Clone detaches these, so
+	// a cloned TestCase can be modified freely as a standalone template.
+	owner     *TestsBuilder[SUT, STATE, ASSERT]
+	entrySlot int
+	caseSlot  int
 }
 
-func (ts *TestCase[SUT, STATE, ASSERT]) WithStateBuilder(f func(t *testing.T, sut *SUT, state *STATE)) *TestCase[SUT, STATE, ASSERT] {
-	ts.StateBuilder = f
-	return ts
+// commit copies tc and, if the copy is attached to a builder, writes the
+// copy back into that builder's slot before returning it.
+func (tc TestCase[SUT, STATE, ASSERT]) commit() *TestCase[SUT, STATE, ASSERT] {
+	cp := &tc
+	if cp.owner != nil {
+		cp.owner.entries[cp.entrySlot].testCase = cp
+		cp.owner.TestCases[cp.caseSlot] = cp
+	}
+	return cp
 }
 
-func (ts *TestCase[SUT, STATE, ASSERT]) WithSpecificBuilder(f func(t *testing.T, sut *SUT, state *STATE)) *TestCase[SUT, STATE, ASSERT] {
-	ts.SpecificBuilder = f
-	return ts
+func (ts *TestCase[SUT, STATE, ASSERT]) WithStateBuilder(f func(t testing.TB, sut *SUT, state *STATE)) *TestCase[SUT, STATE, ASSERT] {
+	cp := *ts
+	cp.StateBuilder = f
+	return cp.commit()
+}
+
+func (ts *TestCase[SUT, STATE, ASSERT]) WithSpecificBuilder(f func(t testing.TB, sut *SUT, state *STATE)) *TestCase[SUT, STATE, ASSERT] {
+	cp := *ts
+	cp.SpecificBuilder = f
+	return cp.commit()
 }
 
 func (ts *TestCase[SUT, STATE, ASSERT]) WithAssertion(f ASSERT) *TestCase[SUT, STATE, ASSERT] {
-	ts.Assertion = f
-	return ts
+	cp := *ts
+	cp.Assertion = f
+	return cp.commit()
+}
+
+// Clone returns a detached shallow copy of ts: a standalone template that
+// isn't registered in any builder, so further With* calls on it won't
+// affect ts or whatever builder ts is registered in. Pass the result to
+// TestsBuilder.Extend to register it as a new case.
+func (ts *TestCase[SUT, STATE, ASSERT]) Clone() *TestCase[SUT, STATE, ASSERT] {
+	cp := *ts
+	cp.owner = nil
+	cp.entrySlot = 0
+	cp.caseSlot = 0
+	return &cp
 }
 
 func (ts *TestsBuilder[SUT, STATE, ASSERT]) Register(name string) *TestCase[SUT, STATE, ASSERT] {
 	testcase := &TestCase[SUT, STATE, ASSERT]{
 		TestName: name,
 	}
+	return ts.attach(testcase)
+}
+
+// Extend registers a new case cloned from base, named name, so a base
+// template forked via Clone (or just left untouched) can seed many
+// variants without the variants affecting each other or base.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) Extend(base *TestCase[SUT, STATE, ASSERT], name string) *TestCase[SUT, STATE, ASSERT] {
+	testcase := base.Clone()
+	testcase.TestName = name
+
+	if base.owner == ts {
+		// base is still registered on ts, so it's already an earlier
+		// ancestor entry in ts's own accumulation chain: its StateBuilder
+		// already runs when building any case after it. Carrying it onto
+		// the clone too would replay it a second time (a third time for a
+		// variant extended from a variant, and so on). SpecificBuilder
+		// only runs for its own case, so the clone keeps it.
+		testcase.StateBuilder = nil
+	}
+
+	return ts.attach(testcase)
+}
+
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) attach(testcase *TestCase[SUT, STATE, ASSERT]) *TestCase[SUT, STATE, ASSERT] {
+	testcase.owner = ts
+	testcase.caseSlot = len(ts.TestCases)
+	testcase.entrySlot = len(ts.entries)
 	ts.TestCases = append(ts.TestCases, testcase)
+	ts.entries = append(ts.entries, entry[SUT, STATE, ASSERT]{testCase: testcase})
 	return testcase
 }
 
-func (ts *TestsBuilder[SUT, STATE, ASSERT]) Tests() iter.Seq2[string, func(t *testing.T) TestData[SUT, STATE, ASSERT]] {
-	return func(yield func(string, func(t *testing.T) TestData[SUT, STATE, ASSERT]) bool) {
-		for i, curcase := range ts.TestCases {
-			build := func(t *testing.T) TestData[SUT, STATE, ASSERT] {
-				var sut SUT
-				var state STATE
+// Context registers a nested sub-builder named name. The sub-builder
+// inherits everything registered on ts before the Context call, and its
+// own cases are named "<ts>/.../name/<case>". Registrations made on ts
+// after the Context call are not visible to it, and registrations made on
+// the sub-builder never leak back out to ts or its siblings.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) Context(name string, fn func(*TestsBuilder[SUT, STATE, ASSERT])) *TestsBuilder[SUT, STATE, ASSERT] {
+	child := &TestsBuilder[SUT, STATE, ASSERT]{name: name}
+	ts.entries = append(ts.entries, entry[SUT, STATE, ASSERT]{child: child})
+	fn(child)
+	return child
+}
 
-				for j, testcase := range ts.TestCases {
-					if builder := testcase.StateBuilder; builder != nil {
-						builder(t, &sut, &state)
-					}
+// BeforeEach registers f to run before every case in this context and its
+// nested contexts, after the parent context's own BeforeEach hooks.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) BeforeEach(f func(t testing.TB, sut *SUT, state *STATE)) *TestsBuilder[SUT, STATE, ASSERT] {
+	ts.beforeEach = append(ts.beforeEach, f)
+	return ts
+}
 
-					if i != j {
-						continue
-					}
+// AfterEach registers f to run via t.Cleanup after each case in this
+// context (and its nested contexts), in reverse registration order.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) AfterEach(f func(t testing.TB, sut *SUT, state *STATE)) *TestsBuilder[SUT, STATE, ASSERT] {
+	ts.afterEach = append(ts.afterEach, f)
+	return ts
+}
 
-					if testcase.SpecificBuilder != nil {
-						testcase.SpecificBuilder(t, &sut, &state)
-					}
+// Around wraps every case in this context and its nested contexts. f must
+// call next to continue building the case; skipping next skips the case.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) Around(f func(t testing.TB, sut *SUT, state *STATE, next func())) *TestsBuilder[SUT, STATE, ASSERT] {
+	ts.around = append(ts.around, f)
+	return ts
+}
 
-					break
-				}
+// Parallel marks this context, and every case nested under it, to call
+// t.Parallel() when built. It has no effect when building with a
+// testing.TB that isn't a *testing.T.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) Parallel() *TestsBuilder[SUT, STATE, ASSERT] {
+	ts.parallel = true
+	return ts
+}
+
+// leaf is a single registered TestCase reached by walking down chain, the
+// root builder through the builder it was registered on, with name holding
+// its fully-qualified "parent/child/case" path.
+type leaf[SUT any, STATE any, ASSERT any] struct {
+	name     string
+	chain    []*TestsBuilder[SUT, STATE, ASSERT]
+	testCase *TestCase[SUT, STATE, ASSERT]
+}
+
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) collectLeaves(prefix string, chain []*TestsBuilder[SUT, STATE, ASSERT]) []leaf[SUT, STATE, ASSERT] {
+	chain = append(append([]*TestsBuilder[SUT, STATE, ASSERT]{}, chain...), ts)
+
+	var leaves []leaf[SUT, STATE, ASSERT]
+	for _, e := range ts.entries {
+		switch {
+		case e.testCase != nil:
+			name := e.testCase.TestName
+			if prefix != "" {
+				name = prefix + "/" + name
+			}
+			leaves = append(leaves, leaf[SUT, STATE, ASSERT]{name: name, chain: chain, testCase: e.testCase})
+		case e.child != nil:
+			childPrefix := e.child.name
+			if prefix != "" {
+				childPrefix = prefix + "/" + childPrefix
+			}
+			leaves = append(leaves, e.child.collectLeaves(childPrefix, chain)...)
+		}
+	}
+	return leaves
+}
+
+// build runs every hook and StateBuilder that is an ancestor of lf, then
+// lf's own SpecificBuilder, using tb throughout. Only entries on the path
+// from the root to lf are ever run: sibling branches under other Contexts,
+// and entries registered after lf's ancestors, are left untouched.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) build(tb testing.TB, lf leaf[SUT, STATE, ASSERT]) TestData[SUT, STATE, ASSERT] {
+	var sut SUT
+	var state STATE
 
-				return TestData[SUT, STATE, ASSERT]{
-					SUT:    sut,
-					State:  state,
-					Assert: curcase.Assertion,
+	runLevel(tb, lf.chain, 0, lf.testCase, &sut, &state, new(bool))
+
+	return TestData[SUT, STATE, ASSERT]{
+		SUT:    sut,
+		State:  state,
+		Assert: lf.testCase.Assertion,
+	}
+}
+
+// runLevel walks chain from idx down to target, using calledParallel to
+// make sure at most one ancestor's Parallel() results in a t.Parallel()
+// call: calling it twice on the same *testing.T panics, so once any
+// ancestor has claimed it the rest of the chain must not call it again.
+func runLevel[SUT any, STATE any, ASSERT any](tb testing.TB, chain []*TestsBuilder[SUT, STATE, ASSERT], idx int, target *TestCase[SUT, STATE, ASSERT], sut *SUT, state *STATE, calledParallel *bool) {
+	node := chain[idx]
+
+	if node.parallel && !*calledParallel {
+		if t, ok := tb.(*testing.T); ok {
+			t.Parallel()
+			*calledParallel = true
+		}
+	}
+
+	for _, before := range node.beforeEach {
+		before(tb, sut, state)
+	}
+
+	if len(node.afterEach) > 0 {
+		tb.Cleanup(func() {
+			for i := len(node.afterEach) - 1; i >= 0; i-- {
+				node.afterEach[i](tb, sut, state)
+			}
+		})
+	}
+
+	body := func() {
+		runEntries(tb, chain, idx, target, sut, state, calledParallel)
+	}
+	for i := len(node.around) - 1; i >= 0; i-- {
+		around := node.around[i]
+		next := body
+		body = func() { around(tb, sut, state, next) }
+	}
+	body()
+}
+
+func runEntries[SUT any, STATE any, ASSERT any](tb testing.TB, chain []*TestsBuilder[SUT, STATE, ASSERT], idx int, target *TestCase[SUT, STATE, ASSERT], sut *SUT, state *STATE, calledParallel *bool) {
+	node := chain[idx]
+
+	var nextNode *TestsBuilder[SUT, STATE, ASSERT]
+	if idx+1 < len(chain) {
+		nextNode = chain[idx+1]
+	}
+
+	for _, e := range node.entries {
+		if e.testCase != nil {
+			if builder := e.testCase.StateBuilder; builder != nil {
+				builder(tb, sut, state)
+			}
+			if e.testCase == target {
+				if e.testCase.SpecificBuilder != nil {
+					e.testCase.SpecificBuilder(tb, sut, state)
 				}
+				return
+			}
+			continue
+		}
+
+		if e.child == nextNode {
+			runLevel(tb, chain, idx+1, target, sut, state, calledParallel)
+			return
+		}
+	}
+}
+
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) Tests() iter.Seq2[string, func(t *testing.T) TestData[SUT, STATE, ASSERT]] {
+	leaves := ts.collectLeaves("", nil)
+	return func(yield func(string, func(t *testing.T) TestData[SUT, STATE, ASSERT]) bool) {
+		for _, lf := range leaves {
+			if !yield(lf.name, func(t *testing.T) TestData[SUT, STATE, ASSERT] {
+				return ts.build(t, lf)
+			}) {
+				return
+			}
+		}
+	}
+}
+
+// Benchmarks mirrors Tests but drives *testing.B, so the same registered
+// StateBuilders/SpecificBuilders can be reused to benchmark each case.
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) Benchmarks() iter.Seq2[string, func(b *testing.B) TestData[SUT, STATE, ASSERT]] {
+	leaves := ts.collectLeaves("", nil)
+	return func(yield func(string, func(b *testing.B) TestData[SUT, STATE, ASSERT]) bool) {
+		for _, lf := range leaves {
+			if !yield(lf.name, func(b *testing.B) TestData[SUT, STATE, ASSERT] {
+				return ts.build(b, lf)
+			}) {
+				return
 			}
+		}
+	}
+}
 
-			if !yield(curcase.TestName, build) {
+// Fuzz drives *testing.F instead of *testing.T, but unlike Tests and
+// Benchmarks it isn't a way to dispatch named sub-targets: *testing.F has
+// no Run method, so the yielded name can't drive anything and is only
+// useful for logging. The intended usage is calling build(f) once per
+// registered case purely for its side effects, so StateBuilders and
+// SpecificBuilders can seed f's corpus (typically via f.Add) before f.Fuzz
+// runs the actual fuzz target:
+//
+//	for _, build := range ts.Fuzz() {
+//		build(f)
+//	}
+//	f.Fuzz(func(t *testing.T, in []byte) { ... })
+func (ts *TestsBuilder[SUT, STATE, ASSERT]) Fuzz() iter.Seq2[string, func(f *testing.F) TestData[SUT, STATE, ASSERT]] {
+	leaves := ts.collectLeaves("", nil)
+	return func(yield func(string, func(f *testing.F) TestData[SUT, STATE, ASSERT]) bool) {
+		for _, lf := range leaves {
+			if !yield(lf.name, func(f *testing.F) TestData[SUT, STATE, ASSERT] {
+				return ts.build(f, lf)
+			}) {
 				return
 			}
 		}
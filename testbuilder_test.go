@@ -0,0 +1,162 @@
+package testbuilder
+
+import (
+	"testing"
+)
+
+type recorder struct {
+	sut   []string
+	order []string
+}
+
+func appendStep(name string) func(t testing.TB, sut *recorder, state *struct{}) {
+	return func(t testing.TB, sut *recorder, state *struct{}) {
+		sut.sut = append(sut.sut, name)
+	}
+}
+
+func TestTests_AccumulatesStateBuildersInOrder(t *testing.T) {
+	b := &TestsBuilder[recorder, struct{}, struct{}]{}
+	b.Register("first").WithStateBuilder(appendStep("first"))
+	b.Register("second").WithStateBuilder(appendStep("second"))
+	b.Register("third").WithStateBuilder(appendStep("third"))
+
+	want := map[string][]string{
+		"first":  {"first"},
+		"second": {"first", "second"},
+		"third":  {"first", "second", "third"},
+	}
+
+	for name, build := range b.Tests() {
+		t.Run(name, func(t *testing.T) {
+			data := build(t)
+			got := data.SUT.sut
+			expected := want[name]
+			if len(got) != len(expected) {
+				t.Fatalf("sut.sut = %v, want %v", got, expected)
+			}
+			for i := range expected {
+				if got[i] != expected[i] {
+					t.Fatalf("sut.sut = %v, want %v", got, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestContext_NestedNamingAndIsolation(t *testing.T) {
+	b := &TestsBuilder[recorder, struct{}, struct{}]{}
+	b.Register("base").WithStateBuilder(appendStep("base"))
+
+	b.Context("admin", func(cb *TestsBuilder[recorder, struct{}, struct{}]) {
+		cb.Register("can delete").WithStateBuilder(appendStep("admin"))
+	})
+	b.Context("user", func(cb *TestsBuilder[recorder, struct{}, struct{}]) {
+		cb.Register("cannot delete").WithStateBuilder(appendStep("user"))
+	})
+
+	results := map[string][]string{}
+	for name, build := range b.Tests() {
+		results[name] = build(t).SUT.sut
+	}
+
+	wantNames := []string{"admin/can delete", "user/cannot delete"}
+	for _, name := range wantNames {
+		if _, ok := results[name]; !ok {
+			t.Fatalf("missing test %q, got %v", name, results)
+		}
+	}
+
+	if got := results["admin/can delete"]; len(got) != 2 || got[0] != "base" || got[1] != "admin" {
+		t.Fatalf("admin/can delete sut = %v, want [base admin]", got)
+	}
+	if got := results["user/cannot delete"]; len(got) != 2 || got[0] != "base" || got[1] != "user" {
+		t.Fatalf("user/cannot delete sut = %v, want [base user]", got)
+	}
+}
+
+func TestAfterEach_RunsOnCleanupInReverseOrder(t *testing.T) {
+	var order []string
+
+	b := &TestsBuilder[struct{}, struct{}, struct{}]{}
+	b.AfterEach(func(t testing.TB, sut *struct{}, state *struct{}) { order = append(order, "first") })
+	b.AfterEach(func(t testing.TB, sut *struct{}, state *struct{}) { order = append(order, "second") })
+	b.Register("case")
+
+	for name, build := range b.Tests() {
+		t.Run(name, func(t *testing.T) {
+			build(t)
+		})
+	}
+
+	want := []string{"second", "first"}
+	if len(order) != len(want) || order[0] != want[0] || order[1] != want[1] {
+		t.Fatalf("cleanup order = %v, want %v", order, want)
+	}
+}
+
+// TestExtend_VariantRunsBaseStateBuilderExactlyOnce is a regression test
+// for a bug where Extend's clone carried base's StateBuilder forward even
+// though base is already an ancestor entry that replays it, so a variant
+// ran the StateBuilder twice (three times for a variant of a variant).
+func TestExtend_VariantRunsBaseStateBuilderExactlyOnce(t *testing.T) {
+	b := &TestsBuilder[struct{}, struct{}, struct{}]{}
+	count := 0
+	base := b.Register("base").WithStateBuilder(func(t testing.TB, sut *struct{}, state *struct{}) { count++ })
+	b.Extend(base, "variant-a").WithSpecificBuilder(func(t testing.TB, sut *struct{}, state *struct{}) {})
+	variantB := b.Extend(base, "variant-b")
+	b.Extend(variantB, "variant-b-1")
+
+	results := map[string]int{}
+	for name, build := range b.Tests() {
+		count = 0
+		build(t)
+		results[name] = count
+	}
+
+	for name, got := range results {
+		if got != 1 {
+			t.Errorf("%s: base StateBuilder ran %d times, want 1", name, got)
+		}
+	}
+}
+
+// TestBuild_MultipleParallelAncestors_CallsParallelOnce is a regression
+// test for a bug where every ancestor Context marked Parallel() called
+// t.Parallel() on the same *testing.T, which panics on the second call.
+func TestBuild_MultipleParallelAncestors_CallsParallelOnce(t *testing.T) {
+	b := &TestsBuilder[struct{}, struct{}, struct{}]{}
+	b.Parallel()
+
+	b.Context("nested", func(cb *TestsBuilder[struct{}, struct{}, struct{}]) {
+		cb.Parallel()
+		cb.Register("case")
+	})
+
+	for name, build := range b.Tests() {
+		t.Run(name, func(t *testing.T) {
+			build(t)
+		})
+	}
+}
+
+// FuzzBuild exercises Fuzz's real usage: call build(f) once per case, for
+// its side effects, to seed f's corpus before f.Fuzz runs. This relies on
+// *testing.F satisfying testing.TB.
+func FuzzBuild(f *testing.F) {
+	var ran []string
+	b := &TestsBuilder[struct{}, struct{}, struct{}]{}
+	b.Register("seed").WithStateBuilder(func(t testing.TB, sut *struct{}, state *struct{}) {
+		ran = append(ran, "seed")
+	})
+
+	for _, build := range b.Fuzz() {
+		build(f)
+	}
+
+	if len(ran) != 1 {
+		f.Fatalf("StateBuilder ran %d times via Fuzz, want 1", len(ran))
+	}
+
+	f.Fuzz(func(t *testing.T, _ byte) {})
+}